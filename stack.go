@@ -0,0 +1,131 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xerrors
+
+import "runtime"
+
+// A StackTracer is implemented by errors that can report the call stack
+// that was active when they were created.
+type StackTracer interface {
+	// StackTrace returns the captured frames, innermost (closest to the
+	// point of capture) first.
+	StackTrace() []runtime.Frame
+}
+
+const maxStackDepth = 64
+
+// callers captures up to maxStackDepth frames from the calling goroutine,
+// skipping skip+2 frames (runtime.Callers itself and this function).
+func callers(skip int) []runtime.Frame {
+	var pcs [maxStackDepth]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	stack := make([]runtime.Frame, 0, n)
+	for {
+		f, more := frames.Next()
+		stack = append(stack, f)
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// WithStack returns an error that wraps err and records the stack of the
+// calling goroutine at the point WithStack was called. The returned error
+// implements StackTracer and Formatter; its FormatError prints the recorded
+// frames when p.Detail() is true. It has no Format method of its own, so
+// fmt's %+v applied to it directly prints only its Error text.
+//
+// If err already implements StackTracer, WithStack returns err unchanged
+// rather than recording a second stack. Errors created by New and Errorf
+// record a single Frame and do not implement StackTracer, so WithStack
+// wraps them.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(StackTracer); ok {
+		return err
+	}
+	return &withStack{err, callers(1)}
+}
+
+type withStack struct {
+	error
+	stack []runtime.Frame
+}
+
+func (e *withStack) Unwrap() error { return e.error }
+
+func (e *withStack) StackTrace() []runtime.Frame { return e.stack }
+
+func (e *withStack) FormatError(p Printer) (next error) {
+	p.Print(e.error)
+	if p.Detail() {
+		printStack(p, uniqueFrames(e.stack, causeStack(e.error)))
+	}
+	return e.error
+}
+
+// causeStack returns the stack recorded by the nearest StackTracer in err's
+// chain, if any, so that a wrapper's own frames can be printed without
+// repeating the ones already shown for its cause. It unwraps past any
+// number of intermediate wrappers (such as the one Errorf produces) rather
+// than checking only err itself, since WithStack's guard against wrapping
+// an error twice means the immediate cause is often not a StackTracer even
+// when a deeper one is.
+func causeStack(err error) []runtime.Frame {
+	for err != nil {
+		if st, ok := err.(StackTracer); ok {
+			return st.StackTrace()
+		}
+		err = Unwrap(err)
+	}
+	return nil
+}
+
+// uniqueFrames returns the leading frames of stack that are not also the
+// trailing frames of tail, comparing from the end of both slices. Nested
+// wraps captured from nearby call sites share most of their stack, and this
+// keeps that shared suffix from being printed once per wrap.
+func uniqueFrames(stack, tail []runtime.Frame) []runtime.Frame {
+	i, j := len(stack)-1, len(tail)-1
+	for i >= 0 && j >= 0 && stack[i] == tail[j] {
+		i--
+		j--
+	}
+	return stack[:i+1]
+}
+
+func printStack(p Printer, stack []runtime.Frame) {
+	for _, f := range stack {
+		p.Printf("%s\n    %s:%d\n", f.Function, f.File, f.Line)
+	}
+}
+
+// WithCallerFrame returns an error that wraps err and attaches a single
+// Frame describing the immediate caller, the same way New and Errorf
+// already do for the errors they create. Use it instead of WithStack when
+// all you need is the call site and not a full stack walk.
+func WithCallerFrame(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withFrame{err, Caller(1)}
+}
+
+type withFrame struct {
+	error
+	frame Frame
+}
+
+func (e *withFrame) Unwrap() error { return e.error }
+
+func (e *withFrame) FormatError(p Printer) (next error) {
+	p.Print(e.error)
+	e.frame.Format(p)
+	return e.error
+}
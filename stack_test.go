@@ -0,0 +1,114 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xerrors_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+// fakePrinter is a minimal Printer used to exercise FormatError directly,
+// without depending on the fmt.State plumbing in format.go.
+type fakePrinter struct {
+	strings.Builder
+	detail bool
+}
+
+func (p *fakePrinter) Print(args ...interface{}) { fmt.Fprint(&p.Builder, args...) }
+func (p *fakePrinter) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(&p.Builder, format, args...)
+}
+func (p *fakePrinter) Detail() bool { return p.detail }
+
+func TestWithStack(t *testing.T) {
+	err := xerrors.New("boom")
+	wrapped := xerrors.WithStack(err)
+
+	st, ok := wrapped.(xerrors.StackTracer)
+	if !ok {
+		t.Fatalf("WithStack result does not implement StackTracer")
+	}
+	if len(st.StackTrace()) == 0 {
+		t.Fatalf("StackTrace() is empty")
+	}
+
+	if xerrors.WithStack(wrapped) != wrapped {
+		t.Errorf("WithStack on an error that already implements StackTracer should return it unchanged")
+	}
+
+	if xerrors.WithStack(nil) != nil {
+		t.Errorf("WithStack(nil) = %v, want nil", xerrors.WithStack(nil))
+	}
+}
+
+func TestWithStackFormatDetail(t *testing.T) {
+	wrapped := xerrors.WithStack(xerrors.New("boom"))
+
+	f, ok := wrapped.(xerrors.Formatter)
+	if !ok {
+		t.Fatalf("WithStack result does not implement Formatter")
+	}
+
+	p := &fakePrinter{detail: true}
+	f.FormatError(p)
+	if !strings.Contains(p.String(), "stack_test.go") {
+		t.Errorf("FormatError detail output = %q, want it to mention this file", p.String())
+	}
+}
+
+func TestWithStackDedup(t *testing.T) {
+	// inner and outer are captured one call apart in the same function, so
+	// their stacks share every frame above that call site. FormatError on
+	// outer should print only the frames that aren't already part of
+	// inner's stack.
+	inner := xerrors.WithStack(xerrors.New("boom"))
+	outer := xerrors.WithStack(xerrors.Errorf("context: %w", inner))
+	outerTracer := outer.(xerrors.StackTracer)
+
+	p := &fakePrinter{detail: true}
+	outer.(xerrors.Formatter).FormatError(p)
+	printedFrames := strings.Count(p.String(), "\n    ")
+	if printedFrames >= len(outerTracer.StackTrace()) {
+		t.Errorf("FormatError printed %d frames, want fewer than the %d captured, since the shared suffix with inner should be trimmed", printedFrames, len(outerTracer.StackTrace()))
+	}
+}
+
+func TestWithCallerFrame(t *testing.T) {
+	wrapped := xerrors.WithCallerFrame(xerrors.New("boom"))
+
+	f, ok := wrapped.(xerrors.Formatter)
+	if !ok {
+		t.Fatalf("WithCallerFrame result does not implement Formatter")
+	}
+	if _, ok := wrapped.(xerrors.StackTracer); ok {
+		t.Errorf("WithCallerFrame result should not implement StackTracer; it only records one frame")
+	}
+
+	p := &fakePrinter{detail: true}
+	f.FormatError(p)
+	if !strings.Contains(p.String(), "stack_test.go") {
+		t.Errorf("FormatError detail output = %q, want it to mention this file", p.String())
+	}
+
+	if xerrors.WithCallerFrame(nil) != nil {
+		t.Errorf("WithCallerFrame(nil) = %v, want nil", xerrors.WithCallerFrame(nil))
+	}
+}
+
+func TestWithStackThroughOpaque(t *testing.T) {
+	wrapped := xerrors.WithStack(xerrors.New("boom"))
+	opaque := xerrors.Opaque(wrapped)
+
+	var st xerrors.StackTracer
+	if !xerrors.As(opaque, &st) {
+		t.Fatalf("As(Opaque(WithStack(err)), &st) = false, want true")
+	}
+	if len(st.StackTrace()) == 0 {
+		t.Errorf("StackTrace() reached through Opaque is empty")
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xerrors
+
+import "sort"
+
+// A Valuer is implemented by errors that carry structured key/value
+// context, typically attached with WithValues.
+type Valuer interface {
+	// Values returns the key/value pairs carried by the error.
+	Values() map[string]interface{}
+}
+
+// WithValues returns an error that wraps err and carries the given
+// key/value pairs as structured context. kv must consist of alternating
+// string keys and values; WithValues panics if it doesn't.
+//
+// Values collects the pairs from every error in err's tree, and FormatError
+// prints them, one per line, in p.Detail() mode.
+func WithValues(err error, kv ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if len(kv)%2 != 0 {
+		panic("xerrors: WithValues requires an even number of key/value arguments")
+	}
+	values := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			panic("xerrors: WithValues key must be a string")
+		}
+		values[key] = kv[i+1]
+	}
+	return &withValues{err, values}
+}
+
+type withValues struct {
+	error
+	values map[string]interface{}
+}
+
+func (e *withValues) Unwrap() error { return e.error }
+
+func (e *withValues) Values() map[string]interface{} { return e.values }
+
+func (e *withValues) FormatError(p Printer) (next error) {
+	p.Print(e.error)
+	if p.Detail() {
+		for _, k := range sortedKeys(e.values) {
+			p.Printf("%s=%v\n", k, e.values[k])
+		}
+	}
+	return e.error
+}
+
+// Values walks err's tree and returns the key/value pairs collected from
+// every error that implements Valuer. A key set by an error closer to err
+// takes precedence over the same key set by one of its causes.
+func Values(err error) map[string]interface{} {
+	out := make(map[string]interface{})
+	Walk(err, func(err error) bool {
+		if v, ok := err.(Valuer); ok {
+			for k, val := range v.Values() {
+				if _, ok := out[k]; !ok {
+					out[k] = val
+				}
+			}
+		}
+		return true
+	})
+	return out
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
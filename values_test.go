@@ -0,0 +1,56 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xerrors_test
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+func TestWithValues(t *testing.T) {
+	err := xerrors.New("boom")
+	withOne := xerrors.WithValues(err, "request_id", "abc")
+	wrapped := xerrors.Errorf("handling request: %w", withOne)
+
+	got := xerrors.Values(wrapped)
+	want := map[string]interface{}{"request_id": "abc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Values(wrapped) = %v, want %v", got, want)
+	}
+
+	if xerrors.WithValues(nil, "k", "v") != nil {
+		t.Errorf("WithValues(nil, ...) should return nil")
+	}
+}
+
+func TestWithValuesPrecedence(t *testing.T) {
+	inner := xerrors.WithValues(xerrors.New("boom"), "k", "inner")
+	outer := xerrors.WithValues(inner, "k", "outer")
+
+	got := xerrors.Values(outer)
+	if got["k"] != "outer" {
+		t.Errorf("Values(outer)[\"k\"] = %v, want %q", got["k"], "outer")
+	}
+}
+
+func TestWithValuesOddArgsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("WithValues with an odd number of kv args should panic")
+		}
+	}()
+	xerrors.WithValues(xerrors.New("boom"), "k")
+}
+
+func TestWithValuesAs(t *testing.T) {
+	var errT errorT
+	wrapped := xerrors.WithValues(xerrors.Errorf("pittied the fool: %w", errorT{}), "k", "v")
+
+	if !xerrors.As(wrapped, &errT) {
+		t.Fatalf("As(WithValues(...), &errT) = false, want true")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xerrors
+
+// Walk performs a pre-order, depth-first traversal of err's error tree,
+// calling fn for each error found, starting with err itself. If fn returns
+// false, Walk stops the traversal immediately, without visiting any further
+// errors.
+//
+// The tree consists of err itself, followed by its children: if err
+// implements Unwrap() []error, its children are the non-nil results of that
+// method; otherwise, if err implements Unwrap() error, its single child is
+// the result of that method. Unwrap() []error takes precedence when an
+// error implements both.
+func Walk(err error, fn func(error) bool) {
+	walk(err, fn)
+}
+
+// walk does the work of Walk, returning false once fn has returned false so
+// that callers higher up the recursion stop too.
+func walk(err error, fn func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !fn(err) {
+		return false
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, err := range x.Unwrap() {
+			if !walk(err, fn) {
+				return false
+			}
+		}
+	case interface{ Unwrap() error }:
+		return walk(x.Unwrap(), fn)
+	}
+	return true
+}
+
+// Any reports whether any error in err's tree matches one of preds.
+func Any(err error, preds ...func(error) bool) bool {
+	found := false
+	Walk(err, func(err error) bool {
+		for _, pred := range preds {
+			if pred(err) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// All reports whether every error in err's tree matches all of preds.
+func All(err error, preds ...func(error) bool) bool {
+	ok := true
+	Walk(err, func(err error) bool {
+		for _, pred := range preds {
+			if !pred(err) {
+				ok = false
+				return false
+			}
+		}
+		return true
+	})
+	return ok
+}
+
+// Find returns the first error in err's tree for which pred returns true,
+// or nil if there is none.
+func Find(err error, pred func(error) bool) error {
+	var found error
+	Walk(err, func(err error) bool {
+		if pred(err) {
+			found = err
+			return false
+		}
+		return true
+	})
+	return found
+}
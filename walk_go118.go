@@ -0,0 +1,24 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+
+package xerrors
+
+// FindAs returns the first error in err's tree that is assignable to T,
+// together with true. If there is no such error, it returns the zero value
+// of T and false.
+func FindAs[T error](err error) (T, bool) {
+	var target T
+	ok := false
+	Walk(err, func(err error) bool {
+		if e, match := err.(T); match {
+			target = e
+			ok = true
+			return false
+		}
+		return true
+	})
+	return target, ok
+}
@@ -0,0 +1,26 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+
+package xerrors_test
+
+import (
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+func TestFindAs(t *testing.T) {
+	wrapped := xerrors.Errorf("pittied the fool: %w", errorT{})
+
+	got, ok := xerrors.FindAs[errorT](wrapped)
+	if !ok || got != (errorT{}) {
+		t.Fatalf("FindAs[errorT](%v) = %v, %v; want {}, true", wrapped, got, ok)
+	}
+
+	if _, ok := xerrors.FindAs[errorD](wrapped); ok {
+		t.Fatalf("FindAs[errorD](%v) = _, true; want false", wrapped)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xerrors_test
+
+import (
+	"testing"
+
+	"golang.org/x/xerrors"
+)
+
+func TestWalk(t *testing.T) {
+	err1 := xerrors.New("1")
+	err2 := xerrors.New("2")
+	joined := xerrors.Join(err1, err2)
+	wrapped := xerrors.Errorf("wrap: %w", joined)
+
+	var visited []error
+	xerrors.Walk(wrapped, func(err error) bool {
+		visited = append(visited, err)
+		return true
+	})
+	want := []error{wrapped, joined, err1, err2}
+	if len(visited) != len(want) {
+		t.Fatalf("Walk visited %v, want %v", visited, want)
+	}
+	for i, err := range want {
+		if visited[i] != err {
+			t.Errorf("Walk visited[%d] = %v, want %v", i, visited[i], err)
+		}
+	}
+
+	visited = nil
+	xerrors.Walk(wrapped, func(err error) bool {
+		visited = append(visited, err)
+		return err != joined
+	})
+	if len(visited) != 2 {
+		t.Errorf("Walk with early stop visited %v, want 2 errors", visited)
+	}
+}
+
+func TestAny(t *testing.T) {
+	err1 := xerrors.New("1")
+	err2 := xerrors.New("2")
+	joined := xerrors.Join(err1, err2)
+
+	if !xerrors.Any(joined, func(err error) bool { return err == err2 }) {
+		t.Errorf("Any(joined, is err2) = false, want true")
+	}
+	if xerrors.Any(joined, func(err error) bool { return err == xerrors.New("3") }) {
+		t.Errorf("Any(joined, is err3) = true, want false")
+	}
+}
+
+func TestAll(t *testing.T) {
+	err1 := xerrors.New("1")
+	err2 := xerrors.New("2")
+	joined := xerrors.Join(err1, err2)
+
+	isNotNil := func(err error) bool { return err != nil }
+	if !xerrors.All(joined, isNotNil) {
+		t.Errorf("All(joined, isNotNil) = false, want true")
+	}
+
+	isErr1 := func(err error) bool { return err == err1 }
+	if xerrors.All(joined, isErr1) {
+		t.Errorf("All(joined, isErr1) = true, want false")
+	}
+}
+
+func TestFind(t *testing.T) {
+	err1 := xerrors.New("1")
+	err2 := xerrors.New("2")
+	joined := xerrors.Join(err1, err2)
+
+	if got := xerrors.Find(joined, func(err error) bool { return err == err2 }); got != err2 {
+		t.Errorf("Find(joined, is err2) = %v, want %v", got, err2)
+	}
+	if got := xerrors.Find(joined, func(err error) bool { return false }); got != nil {
+		t.Errorf("Find(joined, never) = %v, want nil", got)
+	}
+}
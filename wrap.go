@@ -6,6 +6,8 @@ package xerrors
 
 import (
 	"reflect"
+	"runtime"
+	"strings"
 )
 
 // A Wrapper provides context around another error.
@@ -33,6 +35,16 @@ func (e noWrapper) FormatError(p Printer) (next error) {
 	return nil
 }
 
+// StackTrace returns the stack recorded by the wrapped error, if any, so
+// that it remains reachable through As(err, *StackTracer) even though
+// Opaque otherwise hides err's identity and chain.
+func (e noWrapper) StackTrace() []runtime.Frame {
+	if st, ok := e.error.(StackTracer); ok {
+		return st.StackTrace()
+	}
+	return nil
+}
+
 // Unwrap returns the result of calling the Unwrap method on err, if err implements
 // Unwrap. Otherwise, Unwrap returns nil.
 func Unwrap(err error) error {
@@ -43,7 +55,58 @@ func Unwrap(err error) error {
 	return u.Unwrap()
 }
 
-// Is reports whether any error in err's chain matches target.
+// Join returns an error that wraps the given errors. Any nil error values
+// are discarded. Join returns nil if every value in errs is nil.
+//
+// The error formats as the concatenation of the strings obtained by calling
+// the Error method of each element of errs, separated by newlines.
+//
+// A non-nil error returned by Join implements an Unwrap() []error method
+// returning a copy of errs with the nil values removed, and Is and As
+// consider it an interior node of the error tree: they visit the joined
+// error itself and then each of its children, in order.
+//
+// TODO: Errorf does not yet accept multiple %w verbs the way Join lets
+// callers combine errors explicitly; see golang/xerrors#chunk0-1-followup.
+func Join(errs ...error) error {
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+	e := &joinError{errs: make([]error, 0, n)}
+	for _, err := range errs {
+		if err != nil {
+			e.errs = append(e.errs, err)
+		}
+	}
+	return e
+}
+
+type joinError struct {
+	errs []error
+}
+
+func (e *joinError) Error() string {
+	var b strings.Builder
+	for i, err := range e.errs {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+func (e *joinError) Unwrap() []error {
+	return e.errs
+}
+
+// Is reports whether any error in err's tree matches target.
 //
 // An error is considered to match a target if it is equal to that target or if
 // it implements a method Is(error) bool such that Is(target) returns true.
@@ -51,24 +114,20 @@ func Is(err, target error) bool {
 	if target == nil {
 		return err == target
 	}
-	for {
+	return Any(err, func(err error) bool {
 		if err == target {
 			return true
 		}
-		if x, ok := err.(interface{ Is(error) bool }); ok && x.Is(target) {
-			return true
-		}
+		x, ok := err.(interface{ Is(error) bool })
+		return ok && x.Is(target)
 		// TODO: consider supporing target.Is(err). This would allow
 		// user-definable predicates, but also may allow for coping with sloppy
 		// APIs, thereby making it easier to get away with them.
-		if err = Unwrap(err); err == nil {
-			return false
-		}
-	}
+	})
 }
 
 // IsOneOf reports whether any error in the provided slice of errors matches the target.
-// IsOneOf looks at the entire error chain for each error and compares against the provided target.
+// IsOneOf looks at the entire error tree for each error and compares against the provided target.
 //
 // An error is considered to match a target if it is equal to that target or if
 // it implements a method Is(error) bool such that Is(target) returns true.
@@ -85,7 +144,7 @@ func IsOneOf(errs []error, target error) bool {
 	return isOneOf
 }
 
-// As finds the first error in err's chain that matches the type to which target
+// As finds the first error in err's tree that matches the type to which target
 // points, and if so, sets the target to its value and returns true. An error
 // matches a type if it is assignable to the target type, or if it has a method
 // As(interface{}) bool such that As(target) returns true. As will panic if target
@@ -102,16 +161,12 @@ func As(err error, target interface{}) bool {
 		panic("errors: target must be a pointer")
 	}
 	targetType := typ.Elem()
-	for {
+	return Any(err, func(err error) bool {
 		if reflect.TypeOf(err).AssignableTo(targetType) {
 			reflect.ValueOf(target).Elem().Set(reflect.ValueOf(err))
 			return true
 		}
-		if x, ok := err.(interface{ As(interface{}) bool }); ok && x.As(target) {
-			return true
-		}
-		if err = Unwrap(err); err == nil {
-			return false
-		}
-	}
+		x, ok := err.(interface{ As(interface{}) bool })
+		return ok && x.As(target)
+	})
 }
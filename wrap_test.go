@@ -56,6 +56,77 @@ func TestIs(t *testing.T) {
 	}
 }
 
+func TestIsTree(t *testing.T) {
+	err1 := xerrors.New("1")
+	err2 := xerrors.New("2")
+	err3 := xerrors.New("3")
+	joined := xerrors.Join(err1, err2)
+	wrapped := xerrors.Errorf("wrap: %w", joined)
+
+	testCases := []struct {
+		err    error
+		target error
+		match  bool
+	}{
+		{xerrors.Join(err1, err2), err1, true},
+		{xerrors.Join(err1, err2), err2, true},
+		{xerrors.Join(err1, err2), err3, false},
+		{xerrors.Join(nil, err1, nil), err1, true},
+		{xerrors.Join(xerrors.Join(err1), err2), err1, true},
+		{wrapped, err1, true},
+		{wrapped, err2, true},
+		{wrapped, err3, false},
+		{wrapped, joined, true},
+	}
+	for _, tc := range testCases {
+		t.Run("", func(t *testing.T) {
+			if got := xerrors.Is(tc.err, tc.target); got != tc.match {
+				t.Errorf("Is(%v, %v) = %v, want %v", tc.err, tc.target, got, tc.match)
+			}
+		})
+	}
+}
+
+func TestAsTree(t *testing.T) {
+	var errT errorT
+	joined := xerrors.Join(xerrors.New("1"), xerrors.Errorf("pittied the fool: %w", errorT{}))
+
+	if !xerrors.As(joined, &errT) {
+		t.Fatalf("As(%v, %T) = false, want true", joined, &errT)
+	}
+
+	errT = errorT{}
+	noErrT := xerrors.Join(xerrors.New("1"), xerrors.New("2"))
+	if xerrors.As(noErrT, &errT) {
+		t.Fatalf("As(%v, %T) = true, want false", noErrT, &errT)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	err1 := xerrors.New("1")
+	err2 := xerrors.New("2")
+
+	if got := xerrors.Join(); got != nil {
+		t.Errorf("Join() = %v, want nil", got)
+	}
+	if got := xerrors.Join(nil, nil); got != nil {
+		t.Errorf("Join(nil, nil) = %v, want nil", got)
+	}
+
+	joined := xerrors.Join(err1, nil, err2)
+	if want, got := "1\n2", joined.Error(); got != want {
+		t.Errorf("Join(err1, nil, err2).Error() = %q, want %q", got, want)
+	}
+
+	u, ok := joined.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Join result does not implement Unwrap() []error")
+	}
+	if got := u.Unwrap(); len(got) != 2 || got[0] != err1 || got[1] != err2 {
+		t.Errorf("Unwrap() = %v, want [%v %v]", got, err1, err2)
+	}
+}
+
 func TestIsOneOf(t *testing.T) {
 	err1 := xerrors.New("1")
 	erra := xerrors.Errorf("wrap 2: %w", err1)